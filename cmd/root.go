@@ -0,0 +1,21 @@
+/*
+ * Copyright 2021 VMware, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package cmd wires the CLI's cobra commands.
+package cmd
+
+import "github.com/spf13/cobra"
+
+// rootCmd is the CLI's entry point; subcommands register themselves on it
+// via init().
+var rootCmd = &cobra.Command{
+	Use:   "cbctl",
+	Short: "Carbon Black Cloud container CLI",
+}
+
+// Execute runs the CLI.
+func Execute() error {
+	return rootCmd.Execute()
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2021 VMware, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware/carbon-black-cloud-container-cli/internal/terminalui"
+)
+
+// progressFlag backs --progress, shared by every command that renders a bus
+// event stream.
+var progressFlag string
+
+// scanImage is the seam through which this command runs the image scan,
+// which publishes its progress to the bus; it lets the command be wired to
+// the scan engine without this package depending on it directly. The default
+// value does nothing: it returns nil without publishing any events.
+var scanImage = func(image string) error { return nil }
+
+// scanCmd scans a container image for vulnerabilities, rendering its
+// progress with the Display selected by --progress.
+var scanCmd = &cobra.Command{
+	Use:   "scan [image]",
+	Short: "Scan a container image for vulnerabilities",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		display, err := terminalui.Select(terminalui.ProgressMode(progressFlag))
+		if err != nil {
+			return err
+		}
+
+		errCh := make(chan error, 1)
+		go func() { errCh <- scanImage(args[0]) }()
+
+		// DisplayEvents runs in the foreground (and calls os.Exit itself on a
+		// published error) so the command blocks until the event stream ends
+		// instead of returning while the renderer is still draining it.
+		display.DisplayEvents()
+
+		return <-errCh
+	},
+}
+
+func init() {
+	scanCmd.Flags().StringVar(&progressFlag, "progress", string(terminalui.ProgressAuto),
+		"set the progress renderer: auto, tty, plain, or json")
+
+	rootCmd.AddCommand(scanCmd)
+}
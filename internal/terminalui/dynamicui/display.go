@@ -7,18 +7,14 @@
 package dynamicui
 
 import (
-	"context"
 	"fmt"
 	"os"
-	"sync"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/gookit/color"
 	"github.com/sirupsen/logrus"
 	"github.com/vmware/carbon-black-cloud-container-cli/internal/bus"
-	"github.com/vmware/carbon-black-cloud-container-cli/internal/terminalui/component/eventhandler"
-	"github.com/vmware/carbon-black-cloud-container-cli/internal/terminalui/component/frame"
 	"github.com/vmware/carbon-black-cloud-container-cli/pkg/cberr"
-	"github.com/vmware/carbon-black-cloud-container-cli/pkg/presenter"
 )
 
 // Display will help us handle all the incoming events and show them on the terminal.
@@ -29,87 +25,66 @@ func NewDisplay() *Display {
 	return &Display{}
 }
 
-// DisplayEvents will read events from channel, and show them on terminal.
+// DisplayEvents drives a Bubble Tea program whose model/update/view renders
+// incoming bus events as independently animating stages. Once the program
+// quits, the final presenter output (if any) is flushed after Bubble Tea has
+// released the terminal.
 func (d Display) DisplayEvents() {
-	var (
-		displayErr error
-		exitCode   = 0
-	)
+	var exitCode int
 
-	fr := frame.NewFrame(os.Stderr)
-	_ = fr.HideCursor()
+	p := tea.NewProgram(newModel(), tea.WithOutput(os.Stderr))
 
-	defer func() {
-		fr.Append()
-		_ = fr.ShowCursor()
-
-		if displayErr != nil {
-			msg := "Failed to show the ui during the whole process"
-			e := cberr.NewError(cberr.DisplayErr, msg, displayErr)
-			_, _ = fmt.Fprintln(os.Stderr, msg)
-			exitCode = e.ExitCode()
+	go forwardEvents(p)
 
-			logrus.Errorln(e)
-		}
+	finalModel, runErr := p.Run()
 
+	defer func() {
 		if exitCode > 0 {
 			os.Exit(exitCode)
 		}
 	}()
 
-	ctx := context.Background()
-	wg := &sync.WaitGroup{}
-	handler := eventhandler.NewHandler(ctx, wg)
+	if runErr != nil {
+		msg := "Failed to show the ui during the whole process"
+		e := cberr.NewError(cberr.DisplayErr, msg, runErr)
+		_, _ = fmt.Fprintln(os.Stderr, msg)
+		exitCode = e.ExitCode()
+
+		logrus.Errorln(e)
+
+		return
+	}
+
+	m, _ := finalModel.(model)
+	exitCode = m.exitCode
+
+	if m.err != nil {
+		return
+	}
 
-eventLoop:
+	if m.pres == nil {
+		return
+	}
+
+	_, _ = fmt.Fprintln(os.Stdout, color.Bold.Sprint(m.pres.Title()))
+
+	if err := m.pres.Present(os.Stdout); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to show vulnerability results: %v\n", err)
+	}
+
+	if footer := m.pres.Footer(); footer != "" {
+		_, _ = fmt.Fprintln(os.Stdout, color.Bold.Sprint(footer))
+	}
+}
+
+// forwardEvents relays events from bus.EventChan() into the running
+// tea.Program until the bus signals the end of the event stream.
+func forwardEvents(p *tea.Program) {
 	for e := range bus.EventChan() {
-		switch e.Type() {
-		case bus.NewVersionAvailable:
-			msg := color.Magenta.Sprint(e.Value())
-			displayErr = fr.Append().Render(msg)
-		case bus.NewMessageDetected, bus.ValidateFinishedSuccessfully:
-			wg.Wait()
-			msg := color.Bold.Sprint(e.Value())
-			displayErr = fr.Append().Render(msg)
-		case bus.NewErrorDetected:
-			msg := fmt.Sprintf("%s %v", color.Red.Sprint("[Error]"), e.Value())
-			displayErr = fr.Append().Render(msg)
-			exitCode = e.(*bus.ErrorEvent).ExitCode()
-		case bus.PullDockerImage:
-			displayErr = handler.PullDockerImageHandler(fr.Append(), e.Value())
-		case bus.CopyImage:
-			displayErr = handler.CopyImageHandler(fr.Append(), e.Value())
-		case bus.ReadImage:
-			displayErr = handler.ReadImageHandler(fr.Append(), e.Value())
-		case bus.FetchImage:
-			displayErr = handler.FetchImageHandler(fr.Append(), e.Value())
-		case bus.CatalogerStarted:
-			displayErr = handler.CatalogerStartedHandler(fr.Append(), e.Value())
-		case bus.ScanStarted:
-			displayErr = handler.AnalyzeStartedHandler(fr.Append(), e.Value())
-		case bus.ScanFinished, bus.ValidateFinishedWithViolations:
-			wg.Wait()
-			pres := e.Value().(presenter.Presenter)
-
-			fr.Append()
-			displayErr = fr.Append().Render(color.Bold.Sprint(pres.Title()))
-			fr.Append()
-
-			if err := pres.Present(os.Stdout); err != nil {
-				displayErr = fmt.Errorf("failed to show vulnerability results: %v", err)
-			}
-
-			if pres.Footer() != "" {
-				displayErr = fr.Append().Render(color.Bold.Sprint(pres.Footer()))
-			}
-		case bus.CatalogerFinished, bus.ReadLayer:
-			fallthrough
-		default:
-			continue
-		}
+		p.Send(busEventMsg{event: e})
 
-		if e.IsEnd() || displayErr != nil {
-			break eventLoop
+		if e.IsEnd() {
+			return
 		}
 	}
 }
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2021 VMware, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package handler defines the stage lifecycle contract that dynamicui's
+// Bubble Tea model dispatches bus events through, so new event kinds can be
+// wired up without editing the model's event-handling switch.
+package handler
+
+// StageID identifies one running instance of a stage so later Update/Finish
+// calls can address it.
+type StageID string
+
+// Progress is the minimal progress information a StageHandler needs to
+// advance a determinate stage.
+type Progress struct {
+	Current int64
+	Total   int64
+}
+
+// StageHandler knows how to start, update and finish the on-screen
+// representation of one bus.EventType. Implementations live alongside
+// whatever renders them (e.g. dynamicui's spinner/progress stageModel) and
+// are registered with a Registry rather than being special-cased in the
+// model's dispatch loop.
+type StageHandler interface {
+	// Start begins rendering a new stage for the given event value and
+	// returns an identifier that later Update/Finish calls use to address
+	// it.
+	Start(value interface{}) StageID
+	// Update advances a previously started stage's progress.
+	Update(id StageID, p Progress)
+	// Finish marks a stage as complete, optionally with an error.
+	Finish(id StageID, err error)
+}
+
+// Registry maps bus event types (by their string form, to avoid an import
+// cycle on the bus package) to the StageHandler responsible for rendering
+// them.
+type Registry struct {
+	handlers map[string]StageHandler
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{handlers: make(map[string]StageHandler)}
+}
+
+// Register associates eventType with h, replacing any handler previously
+// registered for it.
+func (r *Registry) Register(eventType string, h StageHandler) {
+	r.handlers[eventType] = h
+}
+
+// Lookup returns the StageHandler registered for eventType, if any.
+func (r *Registry) Lookup(eventType string) (StageHandler, bool) {
+	h, ok := r.handlers[eventType]
+
+	return h, ok
+}
@@ -0,0 +1,255 @@
+/*
+ * Copyright 2021 VMware, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package dynamicui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gookit/color"
+	"github.com/vmware/carbon-black-cloud-container-cli/internal/bus"
+	"github.com/vmware/carbon-black-cloud-container-cli/internal/terminalui/dynamicui/handler"
+	"github.com/vmware/carbon-black-cloud-container-cli/pkg/presenter"
+)
+
+// stageDef describes one concurrent pipeline stage: the bus event that
+// starts it, its label, and whether it's rendered as a determinate progress
+// bar (true) or an indeterminate spinner (false). It's the single source of
+// truth newModel registers handlers from and finishStages sweeps over.
+type stageDef struct {
+	event       bus.EventType
+	label       string
+	determinate bool
+}
+
+var stageDefs = []stageDef{
+	{bus.PullDockerImage, "Pulling image", true},
+	{bus.CopyImage, "Copying image", true},
+	{bus.FetchImage, "Fetching image", true},
+	{bus.ReadImage, "Reading image", false},
+	{bus.CatalogerStarted, "Cataloging contents", false},
+	{bus.ScanStarted, "Scanning for vulnerabilities", false},
+}
+
+// model is the root Bubble Tea model for the dynamic progress UI. Per-event
+// rendering is delegated to handler.StageHandlers registered in newModel, so
+// handleEvent stays a small dispatch loop instead of a growing switch.
+type model struct {
+	stages   *stageSet
+	registry *handler.Registry
+
+	messages []string
+
+	pres     presenter.Presenter
+	results  *resultsModel
+	browsing bool
+	err      error
+	exitCode int
+	quitting bool
+}
+
+func newModel() model {
+	stages := newStageSet()
+	registry := handler.NewRegistry()
+
+	for _, def := range stageDefs {
+		id := handler.StageID(def.event)
+
+		if def.determinate {
+			registry.Register(string(def.event), newProgressHandler(stages, id, def.label))
+		} else {
+			registry.Register(string(def.event), newSpinnerHandler(stages, id, def.label))
+		}
+	}
+
+	return model{stages: stages, registry: registry}
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if e, ok := msg.(busEventMsg); ok {
+		return m.handleEvent(e.event)
+	}
+
+	if m.browsing {
+		return m.updateResults(msg)
+	}
+
+	return m.updateStages(msg)
+}
+
+// updateResults forwards input to the results tree-table while it's open,
+// quitting the program once the user presses q.
+func (m model) updateResults(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && key.String() == "q" {
+		m.quitting = true
+
+		return m, tea.Quit
+	}
+
+	updated, cmd := m.results.Update(msg)
+	m.results = updated
+
+	return m, cmd
+}
+
+// handleEvent dispatches a bus.Event to its registered handler.StageHandler,
+// if any, then applies the handful of non-stage transitions (messages,
+// errors, the terminal scan/validate result) that don't fit the stage
+// lifecycle.
+func (m model) handleEvent(e bus.Event) (tea.Model, tea.Cmd) {
+	if h, ok := m.registry.Lookup(string(e.Type())); ok {
+		h.Start(e.Value())
+	}
+
+	switch e.Type() {
+	case bus.NewVersionAvailable:
+		m.messages = append(m.messages, color.Magenta.Sprint(e.Value()))
+	case bus.NewMessageDetected, bus.ValidateFinishedSuccessfully:
+		m.messages = append(m.messages, color.Bold.Sprint(e.Value()))
+	case bus.NewErrorDetected:
+		m.err = fmt.Errorf("%v", e.Value())
+		m.exitCode = e.(*bus.ErrorEvent).ExitCode()
+		m.finishStages(m.err)
+	case bus.CatalogerFinished:
+		m.finishStage(bus.CatalogerStarted, nil)
+	case bus.ScanFinished, bus.ValidateFinishedWithViolations:
+		m.finishStages(nil)
+		m.pres, _ = e.Value().(presenter.Presenter)
+
+		if vuln, ok := e.Value().(Vulnerable); ok {
+			m.results = newResultsModel(vuln.Images())
+			m.browsing = true
+		}
+	case bus.ReadLayer:
+		// no dedicated stage to update; this event type is deliberately
+		// ignored.
+	}
+
+	cmd := m.stages.drainCmd()
+
+	if e.IsEnd() {
+		// When the results tree-table is up, keep the program running so the
+		// user can scroll/filter it; bus.EventChan() being drained doesn't
+		// mean there's nothing left for the user to do with what it produced.
+		if m.browsing {
+			return m, cmd
+		}
+
+		m.quitting = true
+
+		return m, tea.Batch(cmd, tea.Quit)
+	}
+
+	return m, cmd
+}
+
+// finishStage marks a single stage complete via its registered handler.
+func (m model) finishStage(event bus.EventType, err error) {
+	if h, ok := m.registry.Lookup(string(event)); ok {
+		h.Finish(handler.StageID(event), err)
+	}
+}
+
+// finishStages marks every stage complete via its registered handler. Stages
+// with their own *Finished event (CatalogerStarted/CatalogerFinished) will
+// usually already be done by the time this runs; calling Finish again is a
+// no-op for them.
+func (m model) finishStages(err error) {
+	for _, def := range stageDefs {
+		m.finishStage(def.event, err)
+	}
+}
+
+// updateStages forwards a Bubble Tea message (spinner ticks, progress
+// frames, ...) to every live stage so they can animate independently, and
+// polls live monitors through their registered handler's Update on each
+// progressTickMsg.
+func (m model) updateStages(msg tea.Msg) (tea.Model, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0, len(m.stages.order)+1)
+
+	if _, ok := msg.(progressTickMsg); ok {
+		cmds = append(cmds, m.pollMonitors())
+	}
+
+	for _, id := range m.stages.order {
+		stage, ok := m.stages.stages[id]
+		if !ok {
+			continue
+		}
+
+		updated, cmd := stage.Update(msg)
+		m.stages.stages[id] = updated
+
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// pollMonitors reads every determinate stage's live monitor and pushes the
+// reading through its registered handler's Update, rescheduling itself while
+// any stage is still being polled.
+func (m model) pollMonitors() tea.Cmd {
+	polled := false
+
+	for _, id := range m.stages.order {
+		stage, ok := m.stages.stages[id]
+		if !ok || !stage.determinate || stage.monitor == nil || stage.done {
+			continue
+		}
+
+		h, ok := m.registry.Lookup(string(id))
+		if !ok {
+			continue
+		}
+
+		h.Update(id, handler.Progress{Current: stage.monitor.Current(), Total: stage.monitor.Size()})
+		polled = true
+	}
+
+	cmd := m.stages.drainCmd()
+
+	if polled {
+		return tea.Batch(cmd, tickProgress())
+	}
+
+	return cmd
+}
+
+func (m model) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	if m.browsing {
+		return m.results.View()
+	}
+
+	var b strings.Builder
+
+	for _, id := range m.stages.order {
+		if stage, ok := m.stages.stages[id]; ok {
+			fmt.Fprintln(&b, stage.View())
+		}
+	}
+
+	for _, msg := range m.messages {
+		fmt.Fprintln(&b, msg)
+	}
+
+	if m.err != nil {
+		fmt.Fprintf(&b, "%s %v\n", color.Red.Sprint("[Error]"), m.err)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,18 @@
+/*
+ * Copyright 2021 VMware, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package dynamicui
+
+import "github.com/vmware/carbon-black-cloud-container-cli/internal/bus"
+
+// busEventMsg wraps a bus.Event so it can travel through the tea.Program's
+// Update loop alongside Bubble Tea's own internal messages.
+type busEventMsg struct {
+	event bus.Event
+}
+
+// progressTickMsg drives periodic polling of a determinate stage's monitor so
+// its progress bar advances even when no new bus event has arrived.
+type progressTickMsg struct{}
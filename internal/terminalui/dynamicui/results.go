@@ -0,0 +1,252 @@
+/*
+ * Copyright 2021 VMware, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package dynamicui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gookit/color"
+	"github.com/vmware/carbon-black-cloud-container-cli/pkg/presenter"
+)
+
+// Vulnerable is implemented by presenter.Presenter types that can expose
+// their findings as an image/package/vulnerability tree (see
+// presenter.VulnerabilityPresenter). Presenters that don't implement it
+// keep the previous, non-interactive behavior: their flat
+// Present(io.Writer) output is printed once the program quits.
+type Vulnerable interface {
+	Images() []presenter.ImageResult
+}
+
+// row is a single flattened, renderable line of the tree-table.
+type row struct {
+	text     string
+	cve      string
+	url      string
+	severity string
+	hasFix   bool
+	header   bool
+}
+
+// resultsModel is the scrollable tree-table shown once a scan/validation
+// finishes, so results can be browsed, filtered and collapsed instead of
+// scrolling off-screen.
+type resultsModel struct {
+	viewport viewport.Model
+	rows     []row
+	cursor   int
+
+	filtering      bool
+	filter         string
+	fixedOnly      bool
+	hiddenSeverity map[string]bool
+
+	copied  string
+	copyErr error
+}
+
+// severityKeys maps the number keys 1-4 to the severity tier they toggle the
+// visibility of, letting the user collapse/expand results by severity.
+var severityKeys = map[string]string{
+	"1": "Critical",
+	"2": "High",
+	"3": "Medium",
+	"4": "Low",
+}
+
+func newResultsModel(images []presenter.ImageResult) *resultsModel {
+	m := &resultsModel{
+		viewport:       viewport.New(100, 20),
+		rows:           buildRows(images),
+		hiddenSeverity: make(map[string]bool),
+	}
+	m.refresh()
+
+	return m
+}
+
+func buildRows(images []presenter.ImageResult) []row {
+	var rows []row
+
+	for _, img := range images {
+		rows = append(rows, row{text: img.Name, header: true})
+
+		for _, pkg := range img.Packages {
+			rows = append(rows, row{text: "  " + pkg.Name, header: true})
+
+			for _, vuln := range pkg.Vulnerabilities {
+				rows = append(rows, row{
+					text:     fmt.Sprintf("    %s  %s  fixed in %s", vuln.CVE, vuln.Severity, vuln.FixVersion),
+					cve:      vuln.CVE,
+					url:      vuln.URL,
+					severity: vuln.Severity,
+					hasFix:   vuln.FixVersion != "",
+				})
+			}
+		}
+	}
+
+	return rows
+}
+
+// Update handles navigation, the `/` CVE-id filter, `f` fix-availability
+// filter, number-key severity collapse, and `enter` to copy the selected
+// CVE's URL to the clipboard.
+func (m *resultsModel) Update(msg tea.Msg) (*resultsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.filtering {
+			m.updateFilter(msg)
+			break
+		}
+
+		m.updateNavigation(msg)
+	case tea.WindowSizeMsg:
+		m.viewport.Width = msg.Width
+		m.viewport.Height = msg.Height - 2
+		m.refresh()
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+
+	return m, cmd
+}
+
+// updateFilter consumes keystrokes while the user is typing a CVE-id
+// filter, started by `/` and ended by enter or esc.
+func (m *resultsModel) updateFilter(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter, tea.KeyEsc:
+		m.filtering = false
+	case tea.KeyBackspace:
+		if len(m.filter) > 0 {
+			m.filter = m.filter[:len(m.filter)-1]
+		}
+	case tea.KeyRunes:
+		m.filter += string(msg.Runes)
+	}
+
+	m.cursor = 0
+	m.refresh()
+}
+
+// updateNavigation consumes keystrokes outside of filter-entry mode:
+// up/down/j/k to move, `/` to start a CVE-id filter, `f` to toggle hiding
+// findings with no fix available, a digit to collapse/expand a severity
+// tier, and `enter` to copy the selected finding's CVE URL.
+func (m *resultsModel) updateNavigation(msg tea.KeyMsg) {
+	visible := m.visibleRows()
+
+	switch msg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(visible)-1 {
+			m.cursor++
+		}
+	case "/":
+		m.filtering = true
+	case "f":
+		m.fixedOnly = !m.fixedOnly
+		m.cursor = 0
+	case "1", "2", "3", "4":
+		if severity, ok := severityKeys[msg.String()]; ok {
+			m.hiddenSeverity[severity] = !m.hiddenSeverity[severity]
+			m.cursor = 0
+		}
+	case "enter":
+		if m.cursor < len(visible) && visible[m.cursor].cve != "" {
+			url := visible[m.cursor].url
+			if url == "" {
+				url = cveURL(visible[m.cursor].cve)
+			}
+
+			m.copyErr = clipboard.WriteAll(url)
+			m.copied = visible[m.cursor].cve
+		}
+	}
+
+	m.refresh()
+}
+
+// cveURL returns the canonical NVD detail page for a CVE id, used as a
+// fallback when the presenter didn't supply an authoritative URL.
+func cveURL(cve string) string {
+	return fmt.Sprintf("https://nvd.nist.gov/vuln/detail/%s", cve)
+}
+
+// visibleRows returns the rows matching the current CVE-id filter,
+// fix-availability filter and collapsed severity tiers, always keeping
+// group headers so the tree stays navigable.
+func (m *resultsModel) visibleRows() []row {
+	var filtered []row
+
+	for _, r := range m.rows {
+		if r.header {
+			filtered = append(filtered, r)
+			continue
+		}
+
+		if m.filter != "" && !strings.Contains(strings.ToLower(r.cve), strings.ToLower(m.filter)) {
+			continue
+		}
+
+		if m.fixedOnly && !r.hasFix {
+			continue
+		}
+
+		if m.hiddenSeverity[r.severity] {
+			continue
+		}
+
+		filtered = append(filtered, r)
+	}
+
+	return filtered
+}
+
+func (m *resultsModel) refresh() {
+	var b strings.Builder
+
+	for i, r := range m.visibleRows() {
+		line := r.text
+		if r.header {
+			line = color.Bold.Sprint(line)
+		} else if i == m.cursor {
+			line = color.Cyan.Sprint("> ") + line
+		}
+
+		fmt.Fprintln(&b, line)
+	}
+
+	m.viewport.SetContent(b.String())
+}
+
+// View renders the viewport plus a status/keybinding footer, switching to a
+// filter-entry prompt while the user is typing.
+func (m *resultsModel) View() string {
+	if m.filtering {
+		return m.viewport.View() + "\n" + color.Gray.Sprint("filter (CVE id): "+m.filter)
+	}
+
+	footer := "↑/↓ scroll · / filter CVE · f fix available · 1-4 toggle severity · enter copy CVE URL · q quit"
+
+	switch {
+	case m.copyErr != nil:
+		footer = fmt.Sprintf("failed to copy %s: %v · %s", m.copied, m.copyErr, footer)
+	case m.copied != "":
+		footer = fmt.Sprintf("copied %s URL to clipboard · %s", m.copied, footer)
+	}
+
+	return m.viewport.View() + "\n" + color.Gray.Sprint(footer)
+}
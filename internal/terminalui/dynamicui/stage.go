@@ -0,0 +1,125 @@
+/*
+ * Copyright 2021 VMware, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package dynamicui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gookit/color"
+)
+
+// monitorable is satisfied by the progress-tracking values emitted as the
+// payload of determinate bus events (PullDockerImage, CopyImage, FetchImage).
+type monitorable interface {
+	Current() int64
+	Size() int64
+}
+
+// stageModel renders a single concurrent pipeline stage as one line of the
+// overall view, backed by either a Bubbles spinner (indeterminate stages like
+// cataloging/scanning) or a Bubbles progress bar (determinate stages with a
+// byte/row count).
+type stageModel struct {
+	label       string
+	determinate bool
+	spinner     spinner.Model
+	progress    progress.Model
+	monitor     monitorable
+	done        bool
+	err         error
+}
+
+func newSpinnerStage(label string) *stageModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+
+	return &stageModel{label: label, spinner: s}
+}
+
+func newProgressStage(label string, monitor monitorable) *stageModel {
+	return &stageModel{
+		label:       label,
+		determinate: true,
+		progress:    progress.New(progress.WithDefaultGradient()),
+		monitor:     monitor,
+	}
+}
+
+// tickProgress schedules the next poll of a determinate stage's monitor.
+func tickProgress() tea.Cmd {
+	return tea.Tick(150*time.Millisecond, func(time.Time) tea.Msg {
+		return progressTickMsg{}
+	})
+}
+
+// Init starts the stage's spinner animation or, for a determinate stage
+// backed by a live monitor, the periodic poll that advances its bar.
+func (s *stageModel) Init() tea.Cmd {
+	if s.determinate {
+		if s.monitor != nil {
+			return tickProgress()
+		}
+
+		return nil
+	}
+
+	return s.spinner.Tick
+}
+
+// Update advances the stage's spinner/progress animation in response to a
+// Bubble Tea message, returning any follow-up command it scheduled.
+func (s *stageModel) Update(msg tea.Msg) (*stageModel, tea.Cmd) {
+	if s.done {
+		return s, nil
+	}
+
+	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		if s.determinate {
+			return s, nil
+		}
+
+		var cmd tea.Cmd
+		s.spinner, cmd = s.spinner.Update(msg)
+
+		return s, cmd
+	case progress.FrameMsg:
+		if !s.determinate {
+			return s, nil
+		}
+
+		updated, cmd := s.progress.Update(msg)
+		s.progress = updated.(progress.Model)
+
+		return s, cmd
+	}
+
+	return s, nil
+}
+
+// Finish marks the stage complete, recording err if the stage failed.
+func (s *stageModel) Finish(err error) {
+	s.done = true
+	s.err = err
+}
+
+// View renders the stage as a single line.
+func (s *stageModel) View() string {
+	switch {
+	case s.err != nil:
+		return fmt.Sprintf("%s %s: %v", color.Red.Sprint("x"), s.label, s.err)
+	case s.done:
+		return fmt.Sprintf("%s %s", color.Green.Sprint("✓"), s.label)
+	case s.determinate:
+		return fmt.Sprintf("%s %s", s.label, s.progress.View())
+	default:
+		return fmt.Sprintf("%s %s", s.spinner.View(), s.label)
+	}
+}
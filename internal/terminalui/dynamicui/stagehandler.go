@@ -0,0 +1,61 @@
+/*
+ * Copyright 2021 VMware, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package dynamicui
+
+import "github.com/vmware/carbon-black-cloud-container-cli/internal/terminalui/dynamicui/handler"
+
+// spinnerHandler renders an indeterminate stage (no measurable byte/row
+// progress, e.g. cataloging or scanning) as a single spinner line.
+type spinnerHandler struct {
+	stages *stageSet
+	id     handler.StageID
+	label  string
+}
+
+func newSpinnerHandler(stages *stageSet, id handler.StageID, label string) *spinnerHandler {
+	return &spinnerHandler{stages: stages, id: id, label: label}
+}
+
+func (h *spinnerHandler) Start(interface{}) handler.StageID {
+	h.stages.startSpinner(h.id, h.label)
+
+	return h.id
+}
+
+func (h *spinnerHandler) Update(handler.StageID, handler.Progress) {}
+
+func (h *spinnerHandler) Finish(id handler.StageID, err error) {
+	h.stages.finish(id, err)
+}
+
+// progressHandler renders a determinate stage (byte/row progress, e.g.
+// pulling or copying an image) as a single progress bar. When the event
+// value carries a monitorable source, the bar polls it directly; otherwise
+// it advances only via explicit Update calls.
+type progressHandler struct {
+	stages *stageSet
+	id     handler.StageID
+	label  string
+}
+
+func newProgressHandler(stages *stageSet, id handler.StageID, label string) *progressHandler {
+	return &progressHandler{stages: stages, id: id, label: label}
+}
+
+func (h *progressHandler) Start(value interface{}) handler.StageID {
+	mon, _ := value.(monitorable)
+	h.stages.startProgress(h.id, h.label, mon)
+
+	return h.id
+}
+
+func (h *progressHandler) Update(id handler.StageID, p handler.Progress) {
+	h.stages.update(id, p)
+}
+
+func (h *progressHandler) Finish(id handler.StageID, err error) {
+	h.stages.finish(id, err)
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2021 VMware, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package dynamicui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/vmware/carbon-black-cloud-container-cli/internal/terminalui/dynamicui/handler"
+)
+
+// stageSet is the live collection of stageModels keyed by handler.StageID.
+// It backs every registered handler.StageHandler so they can share
+// rendering state with the Bubble Tea model instead of each re-implementing
+// its own bookkeeping. Starting a stage schedules a tea.Cmd (a spinner tick
+// or a progress poll); since handler.StageHandler.Start only returns a
+// StageID, callers retrieve it with drainCmd.
+type stageSet struct {
+	order   []handler.StageID
+	stages  map[handler.StageID]*stageModel
+	pending []tea.Cmd
+}
+
+func newStageSet() *stageSet {
+	return &stageSet{stages: make(map[handler.StageID]*stageModel)}
+}
+
+// startSpinner starts a new indeterminate stage, or returns the existing one
+// if id is already running: Start isn't guaranteed to fire only once per
+// stage (a determinate event can be re-published mid-scan), and re-adding an
+// already-running stage would duplicate its line and grow order unbounded.
+func (s *stageSet) startSpinner(id handler.StageID, label string) *stageModel {
+	if stage, ok := s.stages[id]; ok {
+		return stage
+	}
+
+	stage := newSpinnerStage(label)
+	s.stages[id] = stage
+	s.order = append(s.order, id)
+	s.pending = append(s.pending, stage.Init())
+
+	return stage
+}
+
+// startProgress starts a determinate stage, or returns the existing one if
+// id is already running (see startSpinner). mon may be nil, in which case
+// the stage's percentage only moves in response to explicit update calls
+// (see update) rather than being polled from a live monitor.
+func (s *stageSet) startProgress(id handler.StageID, label string, mon monitorable) *stageModel {
+	if stage, ok := s.stages[id]; ok {
+		return stage
+	}
+
+	stage := newProgressStage(label, mon)
+	s.stages[id] = stage
+	s.order = append(s.order, id)
+	s.pending = append(s.pending, stage.Init())
+
+	return stage
+}
+
+// drainCmd returns a batched command for every stage started since the last
+// call, clearing the pending list.
+func (s *stageSet) drainCmd() tea.Cmd {
+	cmd := tea.Batch(s.pending...)
+	s.pending = nil
+
+	return cmd
+}
+
+// update pushes a progress reading to a determinate stage, whether it was
+// read from a live monitor (the model polls these; see pollMonitors) or
+// pushed by a StageHandler fed by discrete callbacks. The resulting
+// animation command is queued for drainCmd since handler.StageHandler's
+// Update returns nothing.
+func (s *stageSet) update(id handler.StageID, p handler.Progress) {
+	stage, ok := s.stages[id]
+	if !ok || !stage.determinate {
+		return
+	}
+
+	percent := 0.0
+	if p.Total > 0 {
+		percent = float64(p.Current) / float64(p.Total)
+	}
+
+	s.pending = append(s.pending, stage.progress.SetPercent(percent))
+}
+
+// finish marks a running stage complete; a stage that is already done keeps
+// the outcome it finished with.
+func (s *stageSet) finish(id handler.StageID, err error) {
+	if stage, ok := s.stages[id]; ok && !stage.done {
+		stage.Finish(err)
+	}
+}
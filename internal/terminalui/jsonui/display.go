@@ -0,0 +1,143 @@
+/*
+ * Copyright 2021 VMware, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package jsonui provides a display handler that emits the bus event stream
+// as machine-readable JSON, one object per line.
+package jsonui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vmware/carbon-black-cloud-container-cli/internal/bus"
+	"github.com/vmware/carbon-black-cloud-container-cli/pkg/cberr"
+	"github.com/vmware/carbon-black-cloud-container-cli/pkg/presenter"
+)
+
+// event is the JSON representation of a single bus.Event, one line per
+// event, mirroring Docker's jsonmessage stream and BuildKit's
+// --progress=rawjson.
+type event struct {
+	Timestamp       time.Time               `json:"timestamp"`
+	Type            string                  `json:"type"`
+	Stage           string                  `json:"stage,omitempty"`
+	Image           string                  `json:"image,omitempty"`
+	ProgressCurrent int64                   `json:"progress_current,omitempty"`
+	ProgressTotal   int64                   `json:"progress_total,omitempty"`
+	Message         string                  `json:"message,omitempty"`
+	Error           string                  `json:"error,omitempty"`
+	ExitCode        int                     `json:"exit_code,omitempty"`
+	Images          []presenter.ImageResult `json:"images,omitempty"`
+}
+
+// monitorable is satisfied by the progress-tracking values emitted as the
+// payload of determinate bus events (PullDockerImage, CopyImage, FetchImage).
+type monitorable interface {
+	Current() int64
+	Size() int64
+}
+
+// imageNamed is satisfied by event payloads that can report the image they
+// refer to.
+type imageNamed interface {
+	ImageName() string
+}
+
+// Display renders bus events as newline-delimited JSON on stdout, for
+// consumption by CI systems (Jenkins, Tekton, GitHub Actions) that need to
+// parse scan progress and errors deterministically rather than scrape ANSI
+// output.
+type Display struct{}
+
+// NewDisplay will initialize a display instance.
+func NewDisplay() *Display {
+	return &Display{}
+}
+
+// DisplayEvents reads events from the bus and writes one JSON object per
+// line to stdout until bus.Event.IsEnd() is reached.
+func (d Display) DisplayEvents() {
+	var (
+		displayErr error
+		exitCode   = 0
+	)
+
+	enc := json.NewEncoder(os.Stdout)
+
+	defer func() {
+		if displayErr != nil {
+			msg := "Failed to show the ui during the whole process"
+			e := cberr.NewError(cberr.DisplayErr, msg, displayErr)
+			_, _ = fmt.Fprintln(os.Stderr, msg)
+			exitCode = e.ExitCode()
+
+			logrus.Errorln(e)
+		}
+
+		if exitCode > 0 {
+			os.Exit(exitCode)
+		}
+	}()
+
+	for e := range bus.EventChan() {
+		evt := d.toEvent(e)
+
+		if e.Type() == bus.NewErrorDetected {
+			exitCode = evt.ExitCode
+		}
+
+		if err := enc.Encode(evt); err != nil {
+			displayErr = fmt.Errorf("failed to encode event: %w", err)
+		}
+
+		if e.IsEnd() || displayErr != nil {
+			break
+		}
+	}
+}
+
+// toEvent converts a bus.Event into its JSON representation.
+func (d Display) toEvent(e bus.Event) event {
+	evt := event{
+		Timestamp: time.Now(),
+		Type:      string(e.Type()),
+		Stage:     string(e.Type()),
+	}
+
+	switch e.Type() {
+	case bus.NewErrorDetected:
+		evt.Error = fmt.Sprint(e.Value())
+
+		if errEvent, ok := e.(*bus.ErrorEvent); ok {
+			evt.ExitCode = errEvent.ExitCode()
+		}
+	case bus.NewVersionAvailable, bus.NewMessageDetected, bus.ValidateFinishedSuccessfully:
+		evt.Message = fmt.Sprint(e.Value())
+	case bus.PullDockerImage, bus.CopyImage, bus.FetchImage:
+		if mon, ok := e.Value().(monitorable); ok {
+			evt.ProgressCurrent = mon.Current()
+			evt.ProgressTotal = mon.Size()
+		}
+	case bus.ScanFinished, bus.ValidateFinishedWithViolations:
+		if pres, ok := e.Value().(presenter.Presenter); ok {
+			evt.Message = pres.Title()
+		}
+
+		if vuln, ok := e.Value().(interface {
+			Images() []presenter.ImageResult
+		}); ok {
+			evt.Images = vuln.Images()
+		}
+	}
+
+	if named, ok := e.Value().(imageNamed); ok {
+		evt.Image = named.ImageName()
+	}
+
+	return evt
+}
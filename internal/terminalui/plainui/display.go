@@ -0,0 +1,123 @@
+/*
+ * Copyright 2021 VMware, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package plainui provides a non-interactive display handler that prints one
+// line per state transition, for use when stderr is not an attached terminal.
+package plainui
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vmware/carbon-black-cloud-container-cli/internal/bus"
+	"github.com/vmware/carbon-black-cloud-container-cli/pkg/cberr"
+	"github.com/vmware/carbon-black-cloud-container-cli/pkg/presenter"
+)
+
+// Display renders bus events as plain, append-only log lines instead of
+// moving the cursor. It consumes the same bus events as dynamicui.Display
+// but produces output that stays readable in CI logs, `tee`, and other
+// non-VT terminals.
+type Display struct{}
+
+// NewDisplay will initialize a display instance.
+func NewDisplay() *Display {
+	return &Display{}
+}
+
+// DisplayEvents will read events from channel, and print one line per state
+// transition: a stage starting, a stage finishing with its duration, or an
+// error.
+func (d Display) DisplayEvents() {
+	var (
+		displayErr error
+		exitCode   = 0
+		started    = make(map[bus.EventType]time.Time)
+	)
+
+	defer func() {
+		if displayErr != nil {
+			msg := "Failed to show the ui during the whole process"
+			e := cberr.NewError(cberr.DisplayErr, msg, displayErr)
+			_, _ = fmt.Fprintln(os.Stderr, msg)
+			exitCode = e.ExitCode()
+
+			logrus.Errorln(e)
+		}
+
+		if exitCode > 0 {
+			os.Exit(exitCode)
+		}
+	}()
+
+eventLoop:
+	for e := range bus.EventChan() {
+		switch e.Type() {
+		case bus.NewVersionAvailable:
+			_, _ = fmt.Fprintln(os.Stderr, e.Value())
+		case bus.NewMessageDetected, bus.ValidateFinishedSuccessfully:
+			_, _ = fmt.Fprintln(os.Stderr, e.Value())
+		case bus.NewErrorDetected:
+			_, _ = fmt.Fprintf(os.Stderr, "[error] %v\n", e.Value())
+			exitCode = e.(*bus.ErrorEvent).ExitCode()
+		case bus.PullDockerImage:
+			d.start(started, bus.PullDockerImage, "Pulling image")
+		case bus.CopyImage:
+			d.start(started, bus.CopyImage, "Copying image")
+		case bus.ReadImage:
+			d.start(started, bus.ReadImage, "Reading image")
+		case bus.FetchImage:
+			d.start(started, bus.FetchImage, "Fetching image")
+		case bus.CatalogerStarted:
+			d.start(started, bus.CatalogerStarted, "Cataloging contents")
+		case bus.ScanStarted:
+			d.start(started, bus.ScanStarted, "Scanning for vulnerabilities")
+		case bus.CatalogerFinished:
+			d.finish(started, bus.CatalogerStarted, "Cataloging contents")
+		case bus.ScanFinished, bus.ValidateFinishedWithViolations:
+			d.finish(started, bus.ScanStarted, "Scanning for vulnerabilities")
+
+			pres := e.Value().(presenter.Presenter)
+
+			_, _ = fmt.Fprintln(os.Stdout, pres.Title())
+
+			if err := pres.Present(os.Stdout); err != nil {
+				displayErr = fmt.Errorf("failed to show vulnerability results: %v", err)
+			}
+
+			if pres.Footer() != "" {
+				_, _ = fmt.Fprintln(os.Stdout, pres.Footer())
+			}
+		case bus.ReadLayer:
+			fallthrough
+		default:
+			continue
+		}
+
+		if e.IsEnd() || displayErr != nil {
+			break eventLoop
+		}
+	}
+}
+
+// start records a stage's start time and prints a "<label> started" line.
+func (d Display) start(started map[bus.EventType]time.Time, t bus.EventType, label string) {
+	started[t] = time.Now()
+	_, _ = fmt.Fprintf(os.Stderr, "%s started\n", label)
+}
+
+// finish prints a "<label> finished" line, including the elapsed duration
+// when a matching start event was recorded.
+func (d Display) finish(started map[bus.EventType]time.Time, t bus.EventType, label string) {
+	start, ok := started[t]
+	if !ok {
+		_, _ = fmt.Fprintf(os.Stderr, "%s finished\n", label)
+		return
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s finished (took %s)\n", label, time.Since(start).Round(time.Millisecond))
+}
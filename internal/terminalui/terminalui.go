@@ -0,0 +1,67 @@
+/*
+ * Copyright 2021 VMware, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package terminalui selects and constructs the Display responsible for
+// rendering bus events to the user.
+package terminalui
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/vmware/carbon-black-cloud-container-cli/internal/terminalui/dynamicui"
+	"github.com/vmware/carbon-black-cloud-container-cli/internal/terminalui/jsonui"
+	"github.com/vmware/carbon-black-cloud-container-cli/internal/terminalui/plainui"
+)
+
+// noTTYEnvVar lets CI and other non-interactive callers force the plain
+// renderer without passing --progress=plain explicitly.
+const noTTYEnvVar = "CBCTL_NO_TTY"
+
+// ProgressMode selects which Display implementation renders the bus event
+// stream, mirroring Docker/BuildKit's --progress flag.
+type ProgressMode string
+
+const (
+	// ProgressAuto picks tty or plain depending on whether stderr is an
+	// attached terminal.
+	ProgressAuto ProgressMode = "auto"
+	// ProgressTTY forces the interactive, cursor-driven renderer.
+	ProgressTTY ProgressMode = "tty"
+	// ProgressPlain forces the line-oriented, non-cursor renderer.
+	ProgressPlain ProgressMode = "plain"
+	// ProgressJSON forces the newline-delimited JSON renderer.
+	ProgressJSON ProgressMode = "json"
+)
+
+// Display is implemented by every terminal renderer: it consumes bus events
+// until the stream ends.
+type Display interface {
+	DisplayEvents()
+}
+
+// Select returns the Display for mode, resolving ProgressAuto (or an empty
+// mode) to plainui.Display when CBCTL_NO_TTY is set or stderr is not an
+// attached terminal, and to dynamicui.Display otherwise.
+func Select(mode ProgressMode) (Display, error) {
+	switch mode {
+	case "", ProgressAuto:
+		if os.Getenv(noTTYEnvVar) != "" || !term.IsTerminal(int(os.Stderr.Fd())) {
+			return plainui.NewDisplay(), nil
+		}
+
+		return dynamicui.NewDisplay(), nil
+	case ProgressTTY:
+		return dynamicui.NewDisplay(), nil
+	case ProgressPlain:
+		return plainui.NewDisplay(), nil
+	case ProgressJSON:
+		return jsonui.NewDisplay(), nil
+	default:
+		return nil, fmt.Errorf("unknown --progress value %q, expected one of auto, tty, plain, json", mode)
+	}
+}
@@ -0,0 +1,19 @@
+/*
+ * Copyright 2021 VMware, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package presenter renders scan and validation results for the CLI's
+// various output formats.
+package presenter
+
+import "io"
+
+// Presenter is implemented by every scan/validation result renderer: it has
+// a title and an optional footer framing its output, and knows how to write
+// its findings to w.
+type Presenter interface {
+	Title() string
+	Present(w io.Writer) error
+	Footer() string
+}
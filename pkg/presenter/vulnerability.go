@@ -0,0 +1,85 @@
+/*
+ * Copyright 2021 VMware, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package presenter
+
+import (
+	"fmt"
+	"io"
+)
+
+// ImageResult is one scanned image's findings, grouped by package.
+type ImageResult struct {
+	Name     string
+	Packages []PackageResult
+}
+
+// PackageResult is one package's findings, grouped by vulnerability.
+type PackageResult struct {
+	Name            string
+	Vulnerabilities []VulnerabilityResult
+}
+
+// VulnerabilityResult is a single finding.
+type VulnerabilityResult struct {
+	CVE        string
+	Severity   string
+	FixVersion string
+	URL        string
+}
+
+// VulnerabilityPresenter renders a set of image scan results as a flat,
+// indented table. It also implements dynamicui.Vulnerable via Images, so
+// renderers capable of interactive display can present the same findings as
+// a scrollable tree instead.
+type VulnerabilityPresenter struct {
+	images []ImageResult
+}
+
+// NewVulnerabilityPresenter returns a Presenter over the given image
+// results.
+func NewVulnerabilityPresenter(images []ImageResult) *VulnerabilityPresenter {
+	return &VulnerabilityPresenter{images: images}
+}
+
+// Images returns the presenter's findings grouped by image, then package,
+// then vulnerability.
+func (p *VulnerabilityPresenter) Images() []ImageResult {
+	return p.images
+}
+
+// Title returns the presenter's heading line.
+func (p *VulnerabilityPresenter) Title() string {
+	return "Vulnerability Report"
+}
+
+// Footer returns the presenter's trailing line; none is needed here.
+func (p *VulnerabilityPresenter) Footer() string {
+	return ""
+}
+
+// Present writes the findings to w as an indented image/package/
+// vulnerability table.
+func (p *VulnerabilityPresenter) Present(w io.Writer) error {
+	for _, img := range p.images {
+		if _, err := fmt.Fprintln(w, img.Name); err != nil {
+			return err
+		}
+
+		for _, pkg := range img.Packages {
+			if _, err := fmt.Fprintf(w, "  %s\n", pkg.Name); err != nil {
+				return err
+			}
+
+			for _, vuln := range pkg.Vulnerabilities {
+				if _, err := fmt.Fprintf(w, "    %s  %s  fixed in %s\n", vuln.CVE, vuln.Severity, vuln.FixVersion); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}